@@ -0,0 +1,51 @@
+package resourceapply
+
+import (
+	"context"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	batchv1beta1client "k8s.io/client-go/kubernetes/typed/batch/v1beta1"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
+)
+
+// ApplyCronJob merges objectmeta and the job template's pod template annotations, then
+// updates the CronJob if anything changed. Pod template annotations already on the server
+// are preserved rather than clobbered; set ForceRedeploymentAnnotation on required to force
+// a new rollout on the CronJob's next scheduled Job.
+func ApplyCronJob(client batchv1beta1client.CronJobsGetter, recorder events.Recorder, required *batchv1beta1.CronJob) (*batchv1beta1.CronJob, bool, error) {
+	existing, err := client.CronJobs(required.Namespace).Get(context.TODO(), required.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		requiredCopy := required.DeepCopy()
+		actual, err := client.CronJobs(required.Namespace).Create(context.TODO(), resourcemerge.WithCleanLabelsAndAnnotations(requiredCopy).(*batchv1beta1.CronJob), metav1.CreateOptions{})
+		reportCreateEvent(recorder, actual, err)
+		return actual, true, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	existingCopy := existing.DeepCopy()
+	requiredCopy := required.DeepCopy()
+	mergePodTemplateMeta(&existingCopy.Spec.JobTemplate.Spec.Template, &requiredCopy.Spec.JobTemplate.Spec.Template, requiredCopy)
+
+	modified := resourcemerge.BoolPtr(false)
+	resourcemerge.EnsureObjectMeta(modified, &existingCopy.ObjectMeta, requiredCopy.ObjectMeta)
+	if !*modified && equality.Semantic.DeepEqual(existingCopy.Spec, requiredCopy.Spec) {
+		return existingCopy, false, nil
+	}
+
+	if klog.V(4).Enabled() {
+		klog.Infof("CronJob %q changes: %v", required.Name, JSONPatchNoError(existing, existingCopy))
+	}
+
+	existingCopy.Spec = requiredCopy.Spec
+	actual, err := client.CronJobs(required.Namespace).Update(context.TODO(), existingCopy, metav1.UpdateOptions{})
+	reportUpdateEvent(recorder, required, err)
+	return actual, true, err
+}
@@ -0,0 +1,51 @@
+package resourceapply
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
+)
+
+// ApplyDeployment merges objectmeta and the pod template annotations, then updates the
+// Deployment if anything changed. Pod template annotations already on the server (such as
+// "deployment.kubernetes.io/revision") are preserved rather than clobbered; set
+// ForceRedeploymentAnnotation on required to force a new rollout.
+func ApplyDeployment(client appsv1client.DeploymentsGetter, recorder events.Recorder, required *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
+	existing, err := client.Deployments(required.Namespace).Get(context.TODO(), required.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		requiredCopy := required.DeepCopy()
+		actual, err := client.Deployments(required.Namespace).Create(context.TODO(), resourcemerge.WithCleanLabelsAndAnnotations(requiredCopy).(*appsv1.Deployment), metav1.CreateOptions{})
+		reportCreateEvent(recorder, actual, err)
+		return actual, true, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	existingCopy := existing.DeepCopy()
+	requiredCopy := required.DeepCopy()
+	mergePodTemplateMeta(&existingCopy.Spec.Template, &requiredCopy.Spec.Template, requiredCopy)
+
+	modified := resourcemerge.BoolPtr(false)
+	resourcemerge.EnsureObjectMeta(modified, &existingCopy.ObjectMeta, requiredCopy.ObjectMeta)
+	if !*modified && equality.Semantic.DeepEqual(existingCopy.Spec, requiredCopy.Spec) {
+		return existingCopy, false, nil
+	}
+
+	if klog.V(4).Enabled() {
+		klog.Infof("Deployment %q changes: %v", required.Name, JSONPatchNoError(existing, existingCopy))
+	}
+
+	existingCopy.Spec = requiredCopy.Spec
+	actual, err := client.Deployments(required.Namespace).Update(context.TODO(), existingCopy, metav1.UpdateOptions{})
+	reportUpdateEvent(recorder, required, err)
+	return actual, true, err
+}
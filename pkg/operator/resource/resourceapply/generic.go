@@ -2,7 +2,9 @@ package resourceapply
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/klog/v2"
@@ -12,18 +14,18 @@ import (
 
 	"github.com/openshift/api"
 	"github.com/openshift/library-go/pkg/operator/events"
-	corev1 "k8s.io/api/core/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
-	storagev1 "k8s.io/api/storage/v1"
-	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 )
 
 var (
@@ -51,8 +53,49 @@ type ClientHolder struct {
 	kubeClient          kubernetes.Interface
 	apiExtensionsClient apiextensionsclient.Interface
 	kubeInformers       v1helpers.KubeInformersForNamespaces
+
+	applyMode    ApplyMode
+	fieldManager string
+
+	handlers     map[schema.GroupVersionKind]ApplyHandler
+	extraClients map[string]interface{}
+
+	retryPolicy RetryPolicy
+}
+
+// RetryPolicy bounds how many times a conflicting write is retried, and how long to back
+// off between attempts.
+type RetryPolicy struct {
+	Steps    int
+	Duration time.Duration
+	Factor   float64
 }
 
+// DefaultRetryPolicy makes up to 5 attempts with exponential backoff starting at 100ms,
+// mirroring the retry apiserver's own etcd3 store uses to absorb a concurrent writer.
+var DefaultRetryPolicy = RetryPolicy{Steps: 5, Duration: 100 * time.Millisecond, Factor: 2.0}
+
+func (p RetryPolicy) backoff() wait.Backoff {
+	return wait.Backoff{Steps: p.Steps, Duration: p.Duration, Factor: p.Factor}
+}
+
+// ApplyMode selects how ApplyDirectly and GenericApply reconcile the required object against
+// the one on the server.
+type ApplyMode string
+
+const (
+	// ModeThreeWay merges ObjectMeta locally and issues a regular Update, the historical
+	// behavior of this package.
+	ModeThreeWay ApplyMode = "ThreeWay"
+	// ModeServerSide issues a server-side apply Patch instead, letting the API server own
+	// the three-way merge against the field manager set with WithFieldManager.
+	ModeServerSide ApplyMode = "ServerSide"
+)
+
+// defaultFieldManager is used when a ClientHolder in ModeServerSide hasn't been given one
+// via WithFieldManager.
+const defaultFieldManager = "library-go"
+
 func NewClientHolder() *ClientHolder {
 	return &ClientHolder{}
 }
@@ -76,6 +119,85 @@ func (c *ClientHolder) WithAPIExtensionsClient(client apiextensionsclient.Interf
 	return c
 }
 
+// KubeClient returns the kubernetes client held by this ClientHolder, or nil if none was set.
+func (c *ClientHolder) KubeClient() kubernetes.Interface {
+	return c.kubeClient
+}
+
+// WithApplyMode selects how ApplyDirectly reconciles required objects against the server.
+// The zero value, the default, behaves as ModeThreeWay.
+func (c *ClientHolder) WithApplyMode(mode ApplyMode) *ClientHolder {
+	c.applyMode = mode
+	return c
+}
+
+// WithFieldManager sets the field manager used for server-side apply patches issued while
+// in ModeServerSide. It has no effect in ModeThreeWay.
+func (c *ClientHolder) WithFieldManager(name string) *ClientHolder {
+	c.fieldManager = name
+	return c
+}
+
+func (c *ClientHolder) fieldManagerOrDefault() string {
+	if c.fieldManager == "" {
+		return defaultFieldManager
+	}
+	return c.fieldManager
+}
+
+// WithRetry sets the policy used to retry a write that lost a race with another writer in
+// ApplyDirectly's ModeServerSide path (serverSideApply). It has no effect on ModeThreeWay,
+// whose typed appliers (ApplyConfigMap, ApplyDeployment, ...) don't retry on conflict, nor on
+// GenericApply, which takes its own retryPolicy argument since, as a free function, it has no
+// ClientHolder to read this from. Without this, a ClientHolder retries with
+// DefaultRetryPolicy.
+func (c *ClientHolder) WithRetry(policy RetryPolicy) *ClientHolder {
+	c.retryPolicy = policy
+	return c
+}
+
+func (c *ClientHolder) retryPolicyOrDefault() RetryPolicy {
+	if c.retryPolicy == (RetryPolicy{}) {
+		return DefaultRetryPolicy
+	}
+	return c.retryPolicy
+}
+
+// WithHandler registers h as the ApplyHandler for gvk, scoped to this ClientHolder only. It
+// takes precedence over a handler registered package-wide with RegisterHandler, so a single
+// caller can override or add kinds without affecting anyone else using this package.
+func (c *ClientHolder) WithHandler(gvk schema.GroupVersionKind, h ApplyHandler) *ClientHolder {
+	if c.handlers == nil {
+		c.handlers = map[schema.GroupVersionKind]ApplyHandler{}
+	}
+	c.handlers[gvk] = h
+	return c
+}
+
+// WithExtraClient stores a client under key for ApplyHandlers that need a client type this
+// package doesn't carry a dedicated field for (a dynamic client, a route client, etc.).
+// Handlers retrieve it with ExtraClient.
+func (c *ClientHolder) WithExtraClient(key string, client interface{}) *ClientHolder {
+	if c.extraClients == nil {
+		c.extraClients = map[string]interface{}{}
+	}
+	c.extraClients[key] = client
+	return c
+}
+
+// ExtraClient returns the client registered under key via WithExtraClient, or nil if none
+// was set.
+func (c *ClientHolder) ExtraClient(key string) interface{} {
+	return c.extraClients[key]
+}
+
+func (c *ClientHolder) handlerFor(gvk schema.GroupVersionKind) ApplyHandler {
+	if h, ok := c.handlers[gvk]; ok {
+		return h
+	}
+	return handlerRegistry[gvk]
+}
+
 // ApplyDirectly applies the given manifest files to API server.
 func ApplyDirectly(clients *ClientHolder, recorder events.Recorder, manifests AssetFunc, files ...string) []ApplyResult {
 	ret := []ApplyResult{}
@@ -88,7 +210,7 @@ func ApplyDirectly(clients *ClientHolder, recorder events.Recorder, manifests As
 			ret = append(ret, result)
 			continue
 		}
-		requiredObj, _, err := genericCodec.Decode(objBytes, nil, nil)
+		requiredObj, actualGVK, err := genericCodec.Decode(objBytes, nil, nil)
 		if err != nil {
 			result.Error = fmt.Errorf("cannot decode %q: %v", file, err)
 			ret = append(ret, result)
@@ -96,81 +218,10 @@ func ApplyDirectly(clients *ClientHolder, recorder events.Recorder, manifests As
 		}
 		result.Type = fmt.Sprintf("%T", requiredObj)
 
-		// NOTE: Do not add CR resources into this switch otherwise the protobuf client can cause problems.
-		switch t := requiredObj.(type) {
-		case *corev1.Namespace:
-			if clients.kubeClient == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyNamespace(clients.kubeClient.CoreV1(), recorder, t)
-		case *corev1.Service:
-			if clients.kubeClient == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyService(clients.kubeClient.CoreV1(), recorder, t)
-		case *corev1.Pod:
-			if clients.kubeClient == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyPod(clients.kubeClient.CoreV1(), recorder, t)
-		case *corev1.ServiceAccount:
-			if clients.kubeClient == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyServiceAccount(clients.kubeClient.CoreV1(), recorder, t)
-		case *corev1.ConfigMap:
-			client := clients.configMapsGetter()
-			if client == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyConfigMap(client, recorder, t)
-		case *corev1.Secret:
-			client := clients.secretsGetter()
-			if client == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplySecret(client, recorder, t)
-		case *rbacv1.ClusterRole:
-			if clients.kubeClient == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyClusterRole(clients.kubeClient.RbacV1(), recorder, t)
-		case *rbacv1.ClusterRoleBinding:
-			if clients.kubeClient == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyClusterRoleBinding(clients.kubeClient.RbacV1(), recorder, t)
-		case *rbacv1.Role:
-			if clients.kubeClient == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyRole(clients.kubeClient.RbacV1(), recorder, t)
-		case *rbacv1.RoleBinding:
-			if clients.kubeClient == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyRoleBinding(clients.kubeClient.RbacV1(), recorder, t)
-		case *apiextensionsv1beta1.CustomResourceDefinition:
-			if clients.apiExtensionsClient == nil {
-				result.Error = fmt.Errorf("missing apiExtensionsClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyCustomResourceDefinitionV1Beta1(clients.apiExtensionsClient.ApiextensionsV1beta1(), recorder, t)
-		case *apiextensionsv1.CustomResourceDefinition:
-			if clients.apiExtensionsClient == nil {
-				result.Error = fmt.Errorf("missing apiExtensionsClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyCustomResourceDefinitionV1(clients.apiExtensionsClient.ApiextensionsV1(), recorder, t)
-		case *storagev1.StorageClass:
-			if clients.kubeClient == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyStorageClass(clients.kubeClient.StorageV1(), recorder, t)
-		case *storagev1.CSIDriver:
-			if clients.kubeClient == nil {
-				result.Error = fmt.Errorf("missing kubeClient")
-			}
-			result.Result, result.Changed, result.Error = ApplyCSIDriver(clients.kubeClient.StorageV1(), recorder, t)
-		default:
+		// NOTE: Do not add CR resources into genericScheme otherwise the protobuf client can cause problems.
+		if handler := clients.handlerFor(*actualGVK); handler != nil {
+			result.Result, result.Changed, result.Error = handler.Apply(context.TODO(), clients, recorder, requiredObj)
+		} else {
 			result.Error = fmt.Errorf("unhandled type %T", requiredObj)
 		}
 
@@ -190,6 +241,46 @@ func (c *ClientHolder) configMapsGetter() corev1client.ConfigMapsGetter {
 	return v1helpers.CachedConfigMapGetter(c.kubeClient.CoreV1(), c.kubeInformers)
 }
 
+// serverSideApply invokes get to read the live object's resourceVersion, then marshals obj
+// to JSON and invokes patch, which should issue a server-side apply Patch through the
+// appropriate typed client, retrying on a conflict from a concurrent writer per c's
+// RetryPolicy. Changed is reported by comparing the live resourceVersion before the patch
+// against the one the patch returns, since a no-op apply still round-trips through the API
+// server without actually mutating the object. get returning a NotFound error is treated as
+// "no prior resourceVersion" rather than failing the apply.
+func (c *ClientHolder) serverSideApply(obj runtime.Object, get func() (runtime.Object, error), patch func(data []byte, opts metav1.PatchOptions) (runtime.Object, error)) (runtime.Object, bool, error) {
+	beforeResourceVersion := ""
+	if existing, err := get(); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, false, err
+		}
+	} else if beforeMeta, err := meta.Accessor(existing); err == nil {
+		beforeResourceVersion = beforeMeta.GetResourceVersion()
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal %T for server-side apply: %v", obj, err)
+	}
+
+	force := true
+	var actual runtime.Object
+	err = retry.OnError(c.retryPolicyOrDefault().backoff(), apierrors.IsConflict, func() error {
+		var patchErr error
+		actual, patchErr = patch(data, metav1.PatchOptions{FieldManager: c.fieldManagerOrDefault(), Force: &force})
+		return patchErr
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	afterMeta, err := meta.Accessor(actual)
+	if err != nil {
+		return actual, true, nil
+	}
+	return actual, afterMeta.GetResourceVersion() != beforeResourceVersion, nil
+}
+
 func (c *ClientHolder) secretsGetter() corev1client.SecretsGetter {
 	if c.kubeClient == nil {
 		return nil
@@ -211,8 +302,12 @@ type ApplyAdapter interface {
 	ObjectMeta(obj runtime.Object) *metav1.ObjectMeta
 }
 
-// GenericApply merges objectmeta and requires
-func GenericApply(recorder events.Recorder, required runtime.Object, adapter ApplyAdapter) (runtime.Object, bool, error) {
+// GenericApply merges objectmeta and required. It retries on a conflict from a concurrent
+// writer, per retryPolicy, by re-fetching the object, re-merging, and re-evaluating whether
+// required now already matches the server before writing again. GenericApply is a free
+// function with no ClientHolder to read a configured policy from, so callers that have one
+// should pass its retryPolicyOrDefault() rather than DefaultRetryPolicy directly.
+func GenericApply(recorder events.Recorder, required runtime.Object, adapter ApplyAdapter, retryPolicy RetryPolicy) (runtime.Object, bool, error) {
 	existing, err := adapter.Get(context.TODO(), required, metav1.GetOptions{})
 	if apierrors.IsNotFound(err) {
 		requiredCopy := adapter.DeepCopy(required)
@@ -225,21 +320,51 @@ func GenericApply(recorder events.Recorder, required runtime.Object, adapter App
 		return nil, false, err
 	}
 
-	modified := resourcemerge.BoolPtr(false)
-	existingCopy := adapter.DeepCopy(existing)
-	existingObjectMeta := adapter.ObjectMeta(existingCopy)
-
-	resourcemerge.EnsureObjectMeta(modified, existingObjectMeta, *adapter.ObjectMeta(required))
-	contentSame := adapter.DeepEqual(required, existingCopy)
-	if contentSame && !*modified {
-		return existingCopy, false, nil
+	if retryPolicy == (RetryPolicy{}) {
+		retryPolicy = DefaultRetryPolicy
 	}
 
-	if klog.V(4).Enabled() {
-		klog.Infof("%s %q changes: %v", adapter.Kind(), existingObjectMeta.Name, JSONPatchNoError(existing, existingCopy))
-	}
+	var actual runtime.Object
+	var changed, attemptedUpdate bool
+	err = retry.OnError(retryPolicy.backoff(), apierrors.IsConflict, func() error {
+		modified := resourcemerge.BoolPtr(false)
+		existingCopy := adapter.DeepCopy(existing)
+		existingObjectMeta := adapter.ObjectMeta(existingCopy)
+
+		resourcemerge.EnsureObjectMeta(modified, existingObjectMeta, *adapter.ObjectMeta(required))
+		contentSame := adapter.DeepEqual(required, existingCopy)
+		if contentSame && !*modified {
+			// origStateIsCurrent: the cached existing object we just re-merged against
+			// already matches required, so there's nothing left to write.
+			actual, changed = existingCopy, false
+			return nil
+		}
+
+		if klog.V(4).Enabled() {
+			klog.Infof("%s %q changes: %v", adapter.Kind(), existingObjectMeta.Name, JSONPatchNoError(existing, existingCopy))
+		}
+
+		// Carry the resourceVersion we just merged against onto required before submitting
+		// it, so a retry after a conflict writes against the fresh object instead of
+		// resubmitting the same stale resourceVersion forever.
+		adapter.ObjectMeta(required).ResourceVersion = existingObjectMeta.ResourceVersion
 
-	actual, err := adapter.Update(context.TODO(), required, metav1.UpdateOptions{})
-	reportUpdateEvent(recorder, required, err)
-	return actual, true, err
+		attemptedUpdate = true
+		var updateErr error
+		actual, updateErr = adapter.Update(context.TODO(), required, metav1.UpdateOptions{})
+		if apierrors.IsConflict(updateErr) {
+			refreshed, getErr := adapter.Get(context.TODO(), required, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
+			}
+			existing = refreshed
+			return updateErr
+		}
+		changed = updateErr == nil
+		return updateErr
+	})
+	if attemptedUpdate {
+		reportUpdateEvent(recorder, required, err)
+	}
+	return actual, changed, err
 }
@@ -0,0 +1,135 @@
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openshift/library-go/pkg/operator/events/eventstesting"
+)
+
+// configMapAdapter is the ApplyAdapter a ConfigMap applier would use; written here only to
+// exercise GenericApply, since no built-in ConfigMap ApplyAdapter exists in this package yet.
+type configMapAdapter struct {
+	client corev1client.ConfigMapsGetter
+}
+
+func (a configMapAdapter) Create(ctx context.Context, obj runtime.Object, opts metav1.CreateOptions) (runtime.Object, error) {
+	cm := obj.(*corev1.ConfigMap)
+	return a.client.ConfigMaps(cm.Namespace).Create(ctx, cm, opts)
+}
+
+func (a configMapAdapter) Update(ctx context.Context, obj runtime.Object, opts metav1.UpdateOptions) (runtime.Object, error) {
+	cm := obj.(*corev1.ConfigMap)
+	return a.client.ConfigMaps(cm.Namespace).Update(ctx, cm, opts)
+}
+
+func (a configMapAdapter) Get(ctx context.Context, obj runtime.Object, opts metav1.GetOptions) (runtime.Object, error) {
+	cm := obj.(*corev1.ConfigMap)
+	return a.client.ConfigMaps(cm.Namespace).Get(ctx, cm.Name, opts)
+}
+
+func (a configMapAdapter) Kind() string { return "ConfigMap" }
+
+func (a configMapAdapter) DeepCopy(obj runtime.Object) runtime.Object {
+	return obj.(*corev1.ConfigMap).DeepCopy()
+}
+
+func (a configMapAdapter) DeepEqual(obj1, obj2 runtime.Object) bool {
+	return apiequality.Semantic.DeepEqual(obj1.(*corev1.ConfigMap).Data, obj2.(*corev1.ConfigMap).Data)
+}
+
+func (a configMapAdapter) MetaObject(obj runtime.Object) metav1.Object {
+	return obj.(*corev1.ConfigMap)
+}
+
+func (a configMapAdapter) ObjectMeta(obj runtime.Object) *metav1.ObjectMeta {
+	return &obj.(*corev1.ConfigMap).ObjectMeta
+}
+
+// TestGenericApplyRetriesWithFreshResourceVersion guards against a conflict retry resubmitting
+// the same stale resourceVersion forever: a concurrent writer bumps the object's
+// resourceVersion once, the first Update loses the race with a conflict, and the retry must
+// still converge rather than exhausting the retry budget.
+func TestGenericApplyRetriesWithFreshResourceVersion(t *testing.T) {
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns", ResourceVersion: "1"},
+		Data:       map[string]string{"k": "old"},
+	}
+	client := fake.NewSimpleClientset(existing)
+
+	conflictOnce := true
+	client.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if !conflictOnce {
+			return false, nil, nil
+		}
+		conflictOnce = false
+		// Simulate a concurrent writer bumping the resourceVersion out from under us.
+		bumped := existing.DeepCopy()
+		bumped.ResourceVersion = "2"
+		if err := client.Tracker().Update(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, bumped, "ns"); err != nil {
+			return true, nil, err
+		}
+		return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "cm", fmt.Errorf("conflict"))
+	})
+
+	required := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+		Data:       map[string]string{"k": "new"},
+	}
+
+	recorder := eventstesting.NewTestingEventRecorder(t)
+	actual, changed, err := GenericApply(recorder, required, configMapAdapter{client: client.CoreV1()}, DefaultRetryPolicy)
+	if err != nil {
+		t.Fatalf("expected the retry to converge, got error: %v", err)
+	}
+	if !changed {
+		t.Error("expected the update to be reported as changed")
+	}
+	if got := actual.(*corev1.ConfigMap).Data["k"]; got != "new" {
+		t.Errorf("expected the retried update to converge to the required data, got %q", got)
+	}
+}
+
+// TestGenericApplyHonorsCallerRetryPolicy guards against GenericApply hardcoding
+// DefaultRetryPolicy instead of consulting the policy its caller passed in: a policy with a
+// single step must surface the first conflict as an error after one Update attempt instead of
+// retrying it up to DefaultRetryPolicy's five attempts.
+func TestGenericApplyHonorsCallerRetryPolicy(t *testing.T) {
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns", ResourceVersion: "1"},
+		Data:       map[string]string{"k": "old"},
+	}
+	client := fake.NewSimpleClientset(existing)
+
+	attempts := 0
+	client.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		attempts++
+		return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "cm", fmt.Errorf("conflict"))
+	})
+
+	required := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+		Data:       map[string]string{"k": "new"},
+	}
+
+	recorder := eventstesting.NewTestingEventRecorder(t)
+	onlyOneAttempt := RetryPolicy{Steps: 1, Duration: DefaultRetryPolicy.Duration, Factor: DefaultRetryPolicy.Factor}
+	_, _, err := GenericApply(recorder, required, configMapAdapter{client: client.CoreV1()}, onlyOneAttempt)
+	if err == nil {
+		t.Fatal("expected the single-step retry policy to surface the conflict instead of retrying it away")
+	}
+	if attempts != 1 {
+		t.Errorf("expected exactly one Update attempt under a single-step retry policy, got %d", attempts)
+	}
+}
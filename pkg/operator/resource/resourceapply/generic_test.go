@@ -0,0 +1,47 @@
+package resourceapply
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TestServerSideApplyChangedComparesLiveResourceVersion guards against comparing the
+// manifest-decoded object's (always-empty) resourceVersion instead of the live object's: a
+// patch that round-trips to the same resourceVersion must be reported as unchanged.
+func TestServerSideApplyChangedComparesLiveResourceVersion(t *testing.T) {
+	c := NewClientHolder()
+
+	obj := &corev1.ConfigMap{} // decoded from a manifest, so it never carries a resourceVersion
+	existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "5"}}
+
+	get := func() (runtime.Object, error) { return existing, nil }
+
+	t.Run("no-op patch reports unchanged", func(t *testing.T) {
+		noop := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "5"}}
+		patch := func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) { return noop, nil }
+
+		_, changed, err := c.serverSideApply(obj, get, patch)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if changed {
+			t.Error("expected a patch that didn't bump the live resourceVersion to report unchanged")
+		}
+	})
+
+	t.Run("mutating patch reports changed", func(t *testing.T) {
+		mutated := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "6"}}
+		patch := func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) { return mutated, nil }
+
+		_, changed, err := c.serverSideApply(obj, get, patch)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !changed {
+			t.Error("expected a patch that bumped the live resourceVersion to report changed")
+		}
+	})
+}
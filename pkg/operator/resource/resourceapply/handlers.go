@@ -0,0 +1,480 @@
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ApplyHandler reconciles a single decoded object against the server. Consumers that ship
+// their own kinds (custom policies, MachineConfigs, Routes, ...) implement this and register
+// it with RegisterHandler, or ClientHolder.WithHandler for a single caller, instead of
+// forking ApplyDirectly.
+type ApplyHandler interface {
+	// Apply reconciles obj against the server and reports the result, whether anything
+	// changed, and any error encountered.
+	Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error)
+}
+
+// handlerRegistry holds the package-wide handlers consulted by every ApplyDirectly call,
+// keyed by the GroupVersionKind decoded from the manifest.
+var handlerRegistry = map[schema.GroupVersionKind]ApplyHandler{}
+
+// RegisterHandler registers h as the ApplyHandler for gvk for every ApplyDirectly call in
+// the process. Use ClientHolder.WithHandler instead to scope a handler to a single caller.
+func RegisterHandler(gvk schema.GroupVersionKind, h ApplyHandler) {
+	handlerRegistry[gvk] = h
+}
+
+func init() {
+	RegisterHandler(corev1.SchemeGroupVersion.WithKind("Namespace"), namespaceHandler{})
+	RegisterHandler(corev1.SchemeGroupVersion.WithKind("Service"), serviceHandler{})
+	RegisterHandler(corev1.SchemeGroupVersion.WithKind("Pod"), podHandler{})
+	RegisterHandler(corev1.SchemeGroupVersion.WithKind("ServiceAccount"), serviceAccountHandler{})
+	RegisterHandler(corev1.SchemeGroupVersion.WithKind("ConfigMap"), configMapHandler{})
+	RegisterHandler(corev1.SchemeGroupVersion.WithKind("Secret"), secretHandler{})
+	RegisterHandler(rbacv1.SchemeGroupVersion.WithKind("ClusterRole"), clusterRoleHandler{})
+	RegisterHandler(rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding"), clusterRoleBindingHandler{})
+	RegisterHandler(rbacv1.SchemeGroupVersion.WithKind("Role"), roleHandler{})
+	RegisterHandler(rbacv1.SchemeGroupVersion.WithKind("RoleBinding"), roleBindingHandler{})
+	RegisterHandler(apiextensionsv1beta1.SchemeGroupVersion.WithKind("CustomResourceDefinition"), crdV1Beta1Handler{})
+	RegisterHandler(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"), crdV1Handler{})
+	RegisterHandler(storagev1.SchemeGroupVersion.WithKind("StorageClass"), storageClassHandler{})
+	RegisterHandler(storagev1.SchemeGroupVersion.WithKind("CSIDriver"), csiDriverHandler{})
+	RegisterHandler(appsv1.SchemeGroupVersion.WithKind("Deployment"), deploymentHandler{})
+	RegisterHandler(appsv1.SchemeGroupVersion.WithKind("DaemonSet"), daemonSetHandler{})
+	RegisterHandler(appsv1.SchemeGroupVersion.WithKind("StatefulSet"), statefulSetHandler{})
+	RegisterHandler(batchv1.SchemeGroupVersion.WithKind("Job"), jobHandler{})
+	RegisterHandler(batchv1beta1.SchemeGroupVersion.WithKind("CronJob"), cronJobHandler{})
+	RegisterHandler(policyv1beta1.SchemeGroupVersion.WithKind("PodDisruptionBudget"), podDisruptionBudgetHandler{})
+	RegisterHandler(networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"), networkPolicyHandler{})
+	RegisterHandler(networkingv1.SchemeGroupVersion.WithKind("Ingress"), ingressHandler{})
+	RegisterHandler(admissionregistrationv1.SchemeGroupVersion.WithKind("MutatingWebhookConfiguration"), mutatingWebhookConfigurationHandler{})
+	RegisterHandler(admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingWebhookConfiguration"), validatingWebhookConfigurationHandler{})
+}
+
+type namespaceHandler struct{}
+
+func (namespaceHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*corev1.Namespace)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.CoreV1().Namespaces().Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.CoreV1().Namespaces().Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyNamespace(clients.kubeClient.CoreV1(), recorder, t)
+}
+
+type serviceHandler struct{}
+
+func (serviceHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*corev1.Service)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.CoreV1().Services(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.CoreV1().Services(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyService(clients.kubeClient.CoreV1(), recorder, t)
+}
+
+type podHandler struct{}
+
+func (podHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*corev1.Pod)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.CoreV1().Pods(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.CoreV1().Pods(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyPod(clients.kubeClient.CoreV1(), recorder, t)
+}
+
+type serviceAccountHandler struct{}
+
+func (serviceAccountHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*corev1.ServiceAccount)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.CoreV1().ServiceAccounts(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.CoreV1().ServiceAccounts(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyServiceAccount(clients.kubeClient.CoreV1(), recorder, t)
+}
+
+type configMapHandler struct{}
+
+func (configMapHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*corev1.ConfigMap)
+	client := clients.configMapsGetter()
+	if client == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return client.ConfigMaps(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return client.ConfigMaps(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyConfigMap(client, recorder, t)
+}
+
+type secretHandler struct{}
+
+func (secretHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*corev1.Secret)
+	client := clients.secretsGetter()
+	if client == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return client.Secrets(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return client.Secrets(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplySecret(client, recorder, t)
+}
+
+type clusterRoleHandler struct{}
+
+func (clusterRoleHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*rbacv1.ClusterRole)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.RbacV1().ClusterRoles().Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.RbacV1().ClusterRoles().Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyClusterRole(clients.kubeClient.RbacV1(), recorder, t)
+}
+
+type clusterRoleBindingHandler struct{}
+
+func (clusterRoleBindingHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*rbacv1.ClusterRoleBinding)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.RbacV1().ClusterRoleBindings().Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.RbacV1().ClusterRoleBindings().Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyClusterRoleBinding(clients.kubeClient.RbacV1(), recorder, t)
+}
+
+type roleHandler struct{}
+
+func (roleHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*rbacv1.Role)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.RbacV1().Roles(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.RbacV1().Roles(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyRole(clients.kubeClient.RbacV1(), recorder, t)
+}
+
+type roleBindingHandler struct{}
+
+func (roleBindingHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*rbacv1.RoleBinding)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.RbacV1().RoleBindings(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.RbacV1().RoleBindings(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyRoleBinding(clients.kubeClient.RbacV1(), recorder, t)
+}
+
+type crdV1Beta1Handler struct{}
+
+func (crdV1Beta1Handler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*apiextensionsv1beta1.CustomResourceDefinition)
+	if clients.apiExtensionsClient == nil {
+		return nil, false, fmt.Errorf("missing apiExtensionsClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.apiExtensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.apiExtensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyCustomResourceDefinitionV1Beta1(clients.apiExtensionsClient.ApiextensionsV1beta1(), recorder, t)
+}
+
+type crdV1Handler struct{}
+
+func (crdV1Handler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*apiextensionsv1.CustomResourceDefinition)
+	if clients.apiExtensionsClient == nil {
+		return nil, false, fmt.Errorf("missing apiExtensionsClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.apiExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.apiExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyCustomResourceDefinitionV1(clients.apiExtensionsClient.ApiextensionsV1(), recorder, t)
+}
+
+type storageClassHandler struct{}
+
+func (storageClassHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*storagev1.StorageClass)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.StorageV1().StorageClasses().Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.StorageV1().StorageClasses().Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyStorageClass(clients.kubeClient.StorageV1(), recorder, t)
+}
+
+type csiDriverHandler struct{}
+
+func (csiDriverHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*storagev1.CSIDriver)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.StorageV1().CSIDrivers().Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.StorageV1().CSIDrivers().Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyCSIDriver(clients.kubeClient.StorageV1(), recorder, t)
+}
+
+type deploymentHandler struct{}
+
+func (deploymentHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*appsv1.Deployment)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.AppsV1().Deployments(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.AppsV1().Deployments(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyDeployment(clients.kubeClient.AppsV1(), recorder, t)
+}
+
+type daemonSetHandler struct{}
+
+func (daemonSetHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*appsv1.DaemonSet)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.AppsV1().DaemonSets(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.AppsV1().DaemonSets(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyDaemonSet(clients.kubeClient.AppsV1(), recorder, t)
+}
+
+type statefulSetHandler struct{}
+
+func (statefulSetHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*appsv1.StatefulSet)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.AppsV1().StatefulSets(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.AppsV1().StatefulSets(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyStatefulSet(clients.kubeClient.AppsV1(), recorder, t)
+}
+
+type jobHandler struct{}
+
+func (jobHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*batchv1.Job)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.BatchV1().Jobs(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.BatchV1().Jobs(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyJob(clients.kubeClient.BatchV1(), recorder, t)
+}
+
+type cronJobHandler struct{}
+
+func (cronJobHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*batchv1beta1.CronJob)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.BatchV1beta1().CronJobs(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.BatchV1beta1().CronJobs(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyCronJob(clients.kubeClient.BatchV1beta1(), recorder, t)
+}
+
+type podDisruptionBudgetHandler struct{}
+
+func (podDisruptionBudgetHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*policyv1beta1.PodDisruptionBudget)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.PolicyV1beta1().PodDisruptionBudgets(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.PolicyV1beta1().PodDisruptionBudgets(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyPodDisruptionBudget(clients.kubeClient.PolicyV1beta1(), recorder, t)
+}
+
+type networkPolicyHandler struct{}
+
+func (networkPolicyHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*networkingv1.NetworkPolicy)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.NetworkingV1().NetworkPolicies(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.NetworkingV1().NetworkPolicies(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyNetworkPolicy(clients.kubeClient.NetworkingV1(), recorder, t)
+}
+
+type ingressHandler struct{}
+
+func (ingressHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*networkingv1.Ingress)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.NetworkingV1().Ingresses(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.NetworkingV1().Ingresses(t.Namespace).Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyIngress(clients.kubeClient.NetworkingV1(), recorder, t)
+}
+
+type mutatingWebhookConfigurationHandler struct{}
+
+func (mutatingWebhookConfigurationHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*admissionregistrationv1.MutatingWebhookConfiguration)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyMutatingWebhookConfiguration(clients.kubeClient.AdmissionregistrationV1(), recorder, t)
+}
+
+type validatingWebhookConfigurationHandler struct{}
+
+func (validatingWebhookConfigurationHandler) Apply(ctx context.Context, clients *ClientHolder, recorder events.Recorder, obj runtime.Object) (runtime.Object, bool, error) {
+	t := obj.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+	if clients.kubeClient == nil {
+		return nil, false, fmt.Errorf("missing kubeClient")
+	}
+	if clients.applyMode == ModeServerSide {
+		return clients.serverSideApply(t, func() (runtime.Object, error) {
+			return clients.kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(ctx, t.Name, metav1.GetOptions{})
+		}, func(data []byte, opts metav1.PatchOptions) (runtime.Object, error) {
+			return clients.kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Patch(ctx, t.Name, types.ApplyPatchType, data, opts)
+		})
+	}
+	return ApplyValidatingWebhookConfiguration(clients.kubeClient.AdmissionregistrationV1(), recorder, t)
+}
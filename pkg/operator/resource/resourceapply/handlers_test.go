@@ -0,0 +1,46 @@
+package resourceapply
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// TestHandlerRegistryDispatchesByGVK guards against dispatch drifting away from the GVK-keyed
+// handlerRegistry: a built-in kind (here ConfigMap) must be reachable purely by its
+// GroupVersionKind, with no other dispatch mechanism (e.g. a per-handler type assertion) in
+// the loop.
+func TestHandlerRegistryDispatchesByGVK(t *testing.T) {
+	gvk := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+
+	h, ok := handlerRegistry[gvk]
+	if !ok {
+		t.Fatalf("no handler registered for %s", gvk)
+	}
+	if _, ok := h.(configMapHandler); !ok {
+		t.Errorf("expected configMapHandler for %s, got %T", gvk, h)
+	}
+
+	if _, ok := handlerRegistry[schema.GroupVersionKind{Group: "nope", Version: "v1", Kind: "Nope"}]; ok {
+		t.Error("expected no handler for an unregistered GVK")
+	}
+}
+
+// TestClientHolderHandlerOverridesRegistry guards against WithHandler's per-caller override
+// being ignored in favor of the package-wide handlerRegistry.
+func TestClientHolderHandlerOverridesRegistry(t *testing.T) {
+	gvk := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	// A handler type other than the registry's configMapHandler, so the override is observable.
+	override := secretHandler{}
+
+	c := NewClientHolder().WithHandler(gvk, override)
+	if _, ok := c.handlerFor(gvk).(secretHandler); !ok {
+		t.Fatalf("expected the overridden handler to be found, got %T", c.handlerFor(gvk))
+	}
+
+	other := corev1.SchemeGroupVersion.WithKind("Secret")
+	if c.handlerFor(other) == nil {
+		t.Errorf("expected %s to still fall back to the package-wide registry", other)
+	}
+}
@@ -0,0 +1,64 @@
+package resourceapply
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift/library-go/pkg/operator/events/eventstesting"
+)
+
+// TestApplyJobNeverDiffsServerPopulatedSpecFields guards against ApplyJob diffing an existing
+// Job's Spec against required: spec.selector is immutable and spec.template.metadata.labels
+// gets controller-uid/job-name injected by the API server, so any Update touching Spec fails
+// validation on every reconcile of an already-created Job.
+func TestApplyJobNeverDiffsServerPopulatedSpecFields(t *testing.T) {
+	existing := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "ns", Labels: map[string]string{"existing": "true"}},
+		Spec: batchv1.JobSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"controller-uid": "generated-by-server"}},
+		},
+	}
+	client := fake.NewSimpleClientset(existing)
+
+	required := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "ns", Labels: map[string]string{"required": "true"}},
+	}
+
+	recorder := eventstesting.NewTestingEventRecorder(t)
+	actual, changed, err := ApplyJob(client.BatchV1(), recorder, required)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected the ObjectMeta merge to report changed")
+	}
+	if actual.Spec.Selector == nil || actual.Spec.Selector.MatchLabels["controller-uid"] != "generated-by-server" {
+		t.Errorf("expected the server-populated selector to survive untouched, got %#v", actual.Spec.Selector)
+	}
+	if actual.Labels["existing"] != "true" || actual.Labels["required"] != "true" {
+		t.Errorf("expected both the existing and required labels to be present, got %v", actual.Labels)
+	}
+}
+
+func TestApplyJobNoopWhenObjectMetaUnchanged(t *testing.T) {
+	existing := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "ns", Labels: map[string]string{"same": "true"}},
+	}
+	client := fake.NewSimpleClientset(existing)
+
+	required := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "ns", Labels: map[string]string{"same": "true"}},
+	}
+
+	recorder := eventstesting.NewTestingEventRecorder(t)
+	_, changed, err := ApplyJob(client.BatchV1(), recorder, required)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changed {
+		t.Error("expected no-op ObjectMeta to report unchanged")
+	}
+}
@@ -0,0 +1,46 @@
+package resourceapply
+
+import (
+	"context"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	admissionregistrationv1client "k8s.io/client-go/kubernetes/typed/admissionregistration/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
+)
+
+// ApplyMutatingWebhookConfiguration merges objectmeta and updates the
+// MutatingWebhookConfiguration if anything changed.
+func ApplyMutatingWebhookConfiguration(client admissionregistrationv1client.MutatingWebhookConfigurationsGetter, recorder events.Recorder, required *admissionregistrationv1.MutatingWebhookConfiguration) (*admissionregistrationv1.MutatingWebhookConfiguration, bool, error) {
+	existing, err := client.MutatingWebhookConfigurations().Get(context.TODO(), required.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		requiredCopy := required.DeepCopy()
+		actual, err := client.MutatingWebhookConfigurations().Create(context.TODO(), resourcemerge.WithCleanLabelsAndAnnotations(requiredCopy).(*admissionregistrationv1.MutatingWebhookConfiguration), metav1.CreateOptions{})
+		reportCreateEvent(recorder, actual, err)
+		return actual, true, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	existingCopy := existing.DeepCopy()
+	modified := resourcemerge.BoolPtr(false)
+	resourcemerge.EnsureObjectMeta(modified, &existingCopy.ObjectMeta, required.ObjectMeta)
+	if !*modified && equality.Semantic.DeepEqual(existingCopy.Webhooks, required.Webhooks) {
+		return existingCopy, false, nil
+	}
+
+	if klog.V(4).Enabled() {
+		klog.Infof("MutatingWebhookConfiguration %q changes: %v", required.Name, JSONPatchNoError(existing, existingCopy))
+	}
+
+	existingCopy.Webhooks = required.Webhooks
+	actual, err := client.MutatingWebhookConfigurations().Update(context.TODO(), existingCopy, metav1.UpdateOptions{})
+	reportUpdateEvent(recorder, required, err)
+	return actual, true, err
+}
@@ -0,0 +1,32 @@
+package resourceapply
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ForceRedeploymentAnnotation may be set on a required workload's ObjectMeta (Deployment,
+// DaemonSet, StatefulSet, CronJob) to force a new rollout even when the rest of the spec is
+// unchanged: its value is copied onto the pod template, so setting a value that differs from
+// the one already there changes what the workload controller hashes to decide whether a
+// rollout is needed. ApplyJob does not act on it.
+const ForceRedeploymentAnnotation = "operator.openshift.io/force-redeployment"
+
+// mergePodTemplateMeta copies annotations the server already has onto required's pod
+// template that required doesn't itself set, so fields injected by other controllers (such
+// as a Deployment's own "deployment.kubernetes.io/revision") survive an apply instead of
+// being overwritten back to empty. It also carries ForceRedeploymentAnnotation, when set on
+// requiredMeta, onto the template.
+func mergePodTemplateMeta(existing, required *corev1.PodTemplateSpec, requiredMeta metav1.Object) {
+	merged := map[string]string{}
+	for k, v := range existing.Annotations {
+		merged[k] = v
+	}
+	for k, v := range required.Annotations {
+		merged[k] = v
+	}
+	if reason, ok := requiredMeta.GetAnnotations()[ForceRedeploymentAnnotation]; ok {
+		merged[ForceRedeploymentAnnotation] = reason
+	}
+	required.Annotations = merged
+}
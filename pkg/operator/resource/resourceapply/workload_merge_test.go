@@ -0,0 +1,44 @@
+package resourceapply
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMergePodTemplateMetaPreservesControllerAnnotations(t *testing.T) {
+	existing := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			"deployment.kubernetes.io/revision": "7",
+		}},
+	}
+	required := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			"my-operator/config-hash": "abc",
+		}},
+	}
+
+	mergePodTemplateMeta(existing, required, &metav1.ObjectMeta{})
+
+	if required.Annotations["deployment.kubernetes.io/revision"] != "7" {
+		t.Errorf("expected the controller-injected annotation to survive the merge, got %v", required.Annotations)
+	}
+	if required.Annotations["my-operator/config-hash"] != "abc" {
+		t.Errorf("expected required's own annotation to remain, got %v", required.Annotations)
+	}
+}
+
+func TestMergePodTemplateMetaCarriesForceRedeployment(t *testing.T) {
+	existing := &corev1.PodTemplateSpec{}
+	required := &corev1.PodTemplateSpec{}
+	requiredMeta := &metav1.ObjectMeta{Annotations: map[string]string{
+		ForceRedeploymentAnnotation: "operator requested rollout",
+	}}
+
+	mergePodTemplateMeta(existing, required, requiredMeta)
+
+	if required.Annotations[ForceRedeploymentAnnotation] != "operator requested rollout" {
+		t.Errorf("expected the force-redeployment annotation to be copied onto the template, got %v", required.Annotations)
+	}
+}
@@ -0,0 +1,21 @@
+package resourcewait
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
+)
+
+// ApplyDirectlyAndWait applies the given manifest files via resourceapply.ApplyDirectly and
+// then blocks until every applied resource is ready, ctx is cancelled, or timeout elapses.
+// The returned error is the aggregated wait error, if any; per-file apply errors are still
+// reported on the individual ApplyResults as usual.
+func ApplyDirectlyAndWait(ctx context.Context, clients *resourceapply.ClientHolder, recorder events.Recorder, manifests resourceapply.AssetFunc, timeout time.Duration, files ...string) ([]resourceapply.ApplyResult, error) {
+	results := resourceapply.ApplyDirectly(clients, recorder, manifests, files...)
+
+	err := WaitForResources(ctx, clients, results, timeout)
+
+	return results, err
+}
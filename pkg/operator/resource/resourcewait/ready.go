@@ -0,0 +1,133 @@
+package resourcewait
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// isReadyFunc reports whether the named object has reached a ready state. A false result
+// with a nil error means "still waiting"; a non-nil error means the resource cannot become
+// ready and polling should stop.
+type isReadyFunc func(ctx context.Context, client kubernetes.Interface, namespace, name string) (bool, error)
+
+// deploymentReady mirrors the logic in Helm's pkg/kube/wait.go: the controller must have
+// observed the latest spec and rolled every replica to Available before we call it ready.
+func deploymentReady(ctx context.Context, client kubernetes.Interface, namespace, name string) (bool, error) {
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return false, nil
+	}
+	expectedReplicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		expectedReplicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.UpdatedReplicas < expectedReplicas {
+		return false, nil
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return false, nil
+	}
+	return deployment.Status.AvailableReplicas >= expectedReplicas, nil
+}
+
+// daemonSetReady waits for every scheduled pod to be updated and ready.
+func daemonSetReady(ctx context.Context, client kubernetes.Interface, namespace, name string) (bool, error) {
+	daemonSet, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled {
+		return false, nil
+	}
+	return daemonSet.Status.NumberReady >= daemonSet.Status.DesiredNumberScheduled, nil
+}
+
+// statefulSetReady waits for the rollout to finish and every replica to report ready.
+func statefulSetReady(ctx context.Context, client kubernetes.Interface, namespace, name string) (bool, error) {
+	statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	expectedReplicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		expectedReplicas = *statefulSet.Spec.Replicas
+	}
+	if statefulSet.Status.ReadyReplicas < expectedReplicas {
+		return false, nil
+	}
+	if statefulSet.Status.UpdateRevision != "" && statefulSet.Status.CurrentRevision != statefulSet.Status.UpdateRevision {
+		return false, nil
+	}
+	return true, nil
+}
+
+// jobReady waits for the job to report at least one successful completion.
+func jobReady(ctx context.Context, client kubernetes.Interface, namespace, name string) (bool, error) {
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == "Failed" && condition.Status == corev1.ConditionTrue {
+			return false, fmt.Errorf("job %s/%s failed: %s", namespace, name, condition.Message)
+		}
+	}
+	return job.Status.Succeeded > 0, nil
+}
+
+// podReady waits for the PodReady condition, treating a Succeeded pod as ready and a
+// Failed one as a terminal error so polling stops for a pod that has terminated in failure.
+// A crash-looping pod stays in the Running phase and is polled until the wait times out.
+func podReady(ctx context.Context, client kubernetes.Interface, namespace, name string) (bool, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, nil
+	case corev1.PodFailed:
+		return false, fmt.Errorf("pod %s/%s failed: %s", namespace, name, pod.Status.Message)
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
+
+// persistentVolumeClaimReady waits for the claim to be bound to a volume.
+func persistentVolumeClaimReady(ctx context.Context, client kubernetes.Interface, namespace, name string) (bool, error) {
+	pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return pvc.Status.Phase == corev1.ClaimBound, nil
+}
+
+// serviceReady waits for a ClusterIP to be assigned, and additionally for a populated
+// LoadBalancer ingress when the service requests one.
+func serviceReady(ctx context.Context, client kubernetes.Interface, namespace, name string) (bool, error) {
+	service, err := client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		return true, nil
+	}
+	if service.Spec.ClusterIP == "" {
+		return false, nil
+	}
+	if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		return len(service.Status.LoadBalancer.Ingress) > 0, nil
+	}
+	return true, nil
+}
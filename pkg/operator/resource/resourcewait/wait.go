@@ -0,0 +1,97 @@
+// Package resourcewait polls the API server for the readiness of resources applied via
+// resourceapply.ApplyDirectly, so operators don't each have to hand-roll the same
+// wait-for-rollout logic.
+package resourcewait
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
+)
+
+// DefaultPollInterval is the interval at which WaitForResources polls each resource.
+// WaitForResources takes no interval argument, so callers cannot change it.
+const DefaultPollInterval = 2 * time.Second
+
+// readyFuncFor returns the isReadyFunc for the concrete type of obj, and the namespace and
+// name to poll with. The bool return is false for kinds that carry no readiness concept of
+// their own (they're considered ready as soon as ApplyDirectly reports them applied).
+func readyFuncFor(obj interface{}) (isReadyFunc, string, string, bool) {
+	switch t := obj.(type) {
+	case *appsv1.Deployment:
+		return deploymentReady, t.Namespace, t.Name, true
+	case *appsv1.DaemonSet:
+		return daemonSetReady, t.Namespace, t.Name, true
+	case *appsv1.StatefulSet:
+		return statefulSetReady, t.Namespace, t.Name, true
+	case *batchv1.Job:
+		return jobReady, t.Namespace, t.Name, true
+	case *corev1.Pod:
+		return podReady, t.Namespace, t.Name, true
+	case *corev1.PersistentVolumeClaim:
+		return persistentVolumeClaimReady, t.Namespace, t.Name, true
+	case *corev1.Service:
+		return serviceReady, t.Namespace, t.Name, true
+	}
+	return nil, "", "", false
+}
+
+// WaitForResources blocks until every resource in results that has a readiness concept
+// (Deployment, DaemonSet, StatefulSet, Job, Pod, PersistentVolumeClaim, Service) reports
+// ready, ctx is cancelled, or timeout elapses. Results that already failed to apply, or
+// whose kind has no readiness concept, are skipped. Resources are polled concurrently, each
+// against the same deadline, so one stuck resource doesn't eat into the time the others get
+// to become ready; errors are aggregated so a single stuck resource doesn't hide failures in
+// the others.
+func WaitForResources(ctx context.Context, clients *resourceapply.ClientHolder, results []resourceapply.ApplyResult, timeout time.Duration) error {
+	return waitForResources(ctx, clients.KubeClient(), results, timeout, DefaultPollInterval)
+}
+
+func waitForResources(ctx context.Context, kubeClient kubernetes.Interface, results []resourceapply.ApplyResult, timeout, interval time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for _, result := range results {
+		if result.Error != nil || result.Result == nil {
+			continue
+		}
+		isReady, namespace, name, hasReadyFunc := readyFuncFor(result.Result)
+		if !hasReadyFunc {
+			continue
+		}
+
+		resourceType, namespace, name := result.Type, namespace, name
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := wait.PollImmediateUntil(interval, func() (bool, error) {
+				ready, err := isReady(ctx, kubeClient, namespace, name)
+				if err != nil {
+					return false, err
+				}
+				return ready, nil
+			}, ctx.Done())
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s %s/%s: %w", resourceType, namespace, name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,58 @@
+package resourcewait
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
+)
+
+func deploymentWithReplicas(name string, desired, available int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Generation: 1},
+		Spec:       appsv1.DeploymentSpec{Replicas: &desired},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    available,
+			Replicas:           available,
+			AvailableReplicas:  available,
+		},
+	}
+}
+
+// TestWaitForResourcesPollsConcurrently guards against a shared deadline being consumed
+// sequentially: a resource that's already ready must not be reported as timed out just
+// because an earlier, stuck resource used up the whole timeout polling in front of it.
+func TestWaitForResourcesPollsConcurrently(t *testing.T) {
+	ready := deploymentWithReplicas("ready", 1, 1)
+	stuck := deploymentWithReplicas("stuck", 1, 0)
+
+	client := fake.NewSimpleClientset(ready, stuck)
+	results := []resourceapply.ApplyResult{
+		{Type: "*v1.Deployment", Result: ready},
+		{Type: "*v1.Deployment", Result: stuck},
+	}
+
+	start := time.Now()
+	err := waitForResources(context.Background(), client, results, 200*time.Millisecond, 10*time.Millisecond)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an aggregated error for the deployment that never becomes ready")
+	}
+	if strings.Contains(err.Error(), "ns/ready") {
+		t.Errorf("ready resource was reported as failed, a timeout was misattributed to it: %v", err)
+	}
+	if !strings.Contains(err.Error(), "ns/stuck") {
+		t.Errorf("expected the stuck resource in the aggregated error, got: %v", err)
+	}
+	if elapsed > 400*time.Millisecond {
+		t.Errorf("waitForResources took %v, expected total time bounded by the shared timeout regardless of resource count", elapsed)
+	}
+}